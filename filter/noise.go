@@ -0,0 +1,157 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/LIA-Aerospace/go-estimate/rnd"
+)
+
+// NoiseSource draws random noise samples and evaluates their log probability density.
+// Kalman and particle filters use it to draw process and measurement noise from
+// distributions other than the standard Gaussian, e.g. to model outlier-prone sensors.
+type NoiseSource interface {
+	// Sample draws n samples and returns them as columns of an m x n matrix.
+	Sample(n int) (*mat.Dense, error)
+	// LogProb returns the log probability density of x under the noise distribution.
+	LogProb(x mat.Vector) float64
+}
+
+// Gaussian is a NoiseSource that draws samples from a multivariate Gaussian distribution.
+type Gaussian struct {
+	mu   []float64
+	cov  *mat.SymDense
+	chol mat.Cholesky
+}
+
+// NewGaussian creates a Gaussian noise source with mean mu and covariance cov and returns it.
+func NewGaussian(mu []float64, cov *mat.SymDense) (*Gaussian, error) {
+	if len(mu) != cov.Symmetric() {
+		return nil, fmt.Errorf("Invalid mean vector: %v", mu)
+	}
+
+	g := &Gaussian{mu: mu, cov: cov}
+	if ok := g.chol.Factorize(cov); !ok {
+		return nil, fmt.Errorf("Cholesky factorization of covariance failed")
+	}
+
+	return g, nil
+}
+
+// Sample draws n samples from the Gaussian distribution.
+func (g *Gaussian) Sample(n int) (*mat.Dense, error) {
+	cov := mat.NewDense(g.cov.Symmetric(), g.cov.Symmetric(), nil)
+	cov.Copy(g.cov)
+
+	// rnd.WithCovN rejects requests for fewer than 2 samples, so a single sample is
+	// drawn as the first column of two.
+	draw := n
+	if n == 1 {
+		draw = 2
+	}
+
+	samples, err := rnd.WithCovN(cov, draw)
+	if err != nil {
+		return nil, err
+	}
+	if draw != n {
+		samples = samples.Slice(0, g.cov.Symmetric(), 0, n).(*mat.Dense)
+	}
+
+	rows, cols := samples.Dims()
+	for j := 0; j < cols; j++ {
+		for i := 0; i < rows; i++ {
+			samples.Set(i, j, samples.At(i, j)+g.mu[i])
+		}
+	}
+
+	return samples, nil
+}
+
+// LogProb returns the log probability density of x under the Gaussian distribution.
+func (g *Gaussian) LogProb(x mat.Vector) float64 {
+	dim := len(g.mu)
+
+	diff := mat.NewVecDense(dim, nil)
+	for i := 0; i < dim; i++ {
+		diff.SetVec(i, x.AtVec(i)-g.mu[i])
+	}
+
+	y := mat.NewVecDense(dim, nil)
+	if err := g.chol.SolveVecTo(y, diff); err != nil {
+		return math.Inf(-1)
+	}
+
+	maha := mat.Dot(diff, y)
+	logSqrtDet := 0.5 * g.chol.LogDet()
+
+	return -0.5*maha - logSqrtDet - float64(dim)/2*math.Log(2*math.Pi)
+}
+
+// StudentsT is a NoiseSource that draws samples from a multivariate Student's-T distribution.
+type StudentsT struct {
+	mu    []float64
+	sigma *mat.SymDense
+	nu    float64
+	chol  mat.Cholesky
+}
+
+// NewStudentsT creates a Student's-T noise source with mean mu, scale matrix sigma and nu
+// degrees of freedom and returns it.
+func NewStudentsT(mu []float64, sigma *mat.SymDense, nu float64) (*StudentsT, error) {
+	if nu <= 0 {
+		return nil, fmt.Errorf("Invalid degrees of freedom: %f", nu)
+	}
+
+	if len(mu) != sigma.Symmetric() {
+		return nil, fmt.Errorf("Invalid mean vector: %v", mu)
+	}
+
+	t := &StudentsT{mu: mu, sigma: sigma, nu: nu}
+	if ok := t.chol.Factorize(sigma); !ok {
+		return nil, fmt.Errorf("Cholesky factorization of scale matrix failed")
+	}
+
+	return t, nil
+}
+
+// Sample draws n samples from the Student's-T distribution.
+func (t *StudentsT) Sample(n int) (*mat.Dense, error) {
+	// rnd.WithStudentsTN rejects requests for fewer than 2 samples, so a single sample
+	// is drawn as the first column of two.
+	if n == 1 {
+		samples, err := rnd.WithStudentsTN(t.mu, t.sigma, t.nu, 2)
+		if err != nil {
+			return nil, err
+		}
+		return samples.Slice(0, len(t.mu), 0, 1).(*mat.Dense), nil
+	}
+
+	return rnd.WithStudentsTN(t.mu, t.sigma, t.nu, n)
+}
+
+// LogProb returns the log probability density of x under the Student's-T distribution.
+func (t *StudentsT) LogProb(x mat.Vector) float64 {
+	dim := float64(len(t.mu))
+
+	diff := mat.NewVecDense(len(t.mu), nil)
+	for i := range t.mu {
+		diff.SetVec(i, x.AtVec(i)-t.mu[i])
+	}
+
+	y := mat.NewVecDense(len(t.mu), nil)
+	if err := t.chol.SolveVecTo(y, diff); err != nil {
+		return math.Inf(-1)
+	}
+
+	maha := mat.Dot(diff, y)
+	logSqrtDet := 0.5 * t.chol.LogDet()
+
+	lgammaNum, _ := math.Lgamma((t.nu + dim) / 2)
+	lgammaDen, _ := math.Lgamma(t.nu / 2)
+
+	return lgammaNum - lgammaDen - dim/2*math.Log(t.nu*math.Pi) - logSqrtDet -
+		(t.nu+dim)/2*math.Log(1+maha/t.nu)
+}
@@ -0,0 +1,82 @@
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestGaussianLogProb(t *testing.T) {
+	mu := []float64{0, 0}
+	cov := mat.NewSymDense(2, []float64{1, 0, 0, 1})
+
+	g, err := NewGaussian(mu, cov)
+	if err != nil {
+		t.Fatalf("NewGaussian failed: %v", err)
+	}
+
+	x := mat.NewVecDense(2, []float64{0, 0})
+	got := g.LogProb(x)
+	want := -math.Log(2 * math.Pi) // standard bivariate normal density at the origin
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("LogProb at origin = %f, want %f", got, want)
+	}
+}
+
+func TestGaussianSample(t *testing.T) {
+	mu := []float64{5, -3}
+	cov := mat.NewSymDense(2, []float64{1, 0, 0, 1})
+
+	g, err := NewGaussian(mu, cov)
+	if err != nil {
+		t.Fatalf("NewGaussian failed: %v", err)
+	}
+
+	samples, err := g.Sample(2000)
+	if err != nil {
+		t.Fatalf("Sample failed: %v", err)
+	}
+
+	_, cols := samples.Dims()
+	var sum0, sum1 float64
+	for j := 0; j < cols; j++ {
+		sum0 += samples.At(0, j)
+		sum1 += samples.At(1, j)
+	}
+	mean0, mean1 := sum0/float64(cols), sum1/float64(cols)
+	if math.Abs(mean0-mu[0]) > 0.15 || math.Abs(mean1-mu[1]) > 0.15 {
+		t.Errorf("Sample means too far from mu: %f, %f", mean0, mean1)
+	}
+}
+
+func TestStudentsTLogProbApproachesGaussianForLargeNu(t *testing.T) {
+	mu := []float64{0, 0}
+	sigma := mat.NewSymDense(2, []float64{1, 0, 0, 1})
+
+	tDist, err := NewStudentsT(mu, sigma, 1e6)
+	if err != nil {
+		t.Fatalf("NewStudentsT failed: %v", err)
+	}
+
+	g, err := NewGaussian(mu, sigma)
+	if err != nil {
+		t.Fatalf("NewGaussian failed: %v", err)
+	}
+
+	x := mat.NewVecDense(2, []float64{0.5, -0.5})
+	gotT := tDist.LogProb(x)
+	gotG := g.LogProb(x)
+	if math.Abs(gotT-gotG) > 1e-3 {
+		t.Errorf("StudentsT.LogProb(%v) = %f, want close to Gaussian.LogProb = %f", x, gotT, gotG)
+	}
+}
+
+func TestNewStudentsTInvalidNu(t *testing.T) {
+	mu := []float64{0, 0}
+	sigma := mat.NewSymDense(2, []float64{1, 0, 0, 1})
+
+	if _, err := NewStudentsT(mu, sigma, -1); err == nil {
+		t.Error("Expected error for non-positive nu")
+	}
+}
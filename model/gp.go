@@ -0,0 +1,285 @@
+package model
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// GP is a Gaussian Process model of a dynamical system whose dynamics are learned
+// from training tuples (x_k, u_k) -> x_{k+1} rather than specified via A/B/C/D
+// matrices. It implements the same Propagate/Observe/Dims interface as Fall so it
+// can be used wherever a closed-form model would otherwise be required; Observe
+// assumes the full state is measured directly.
+type GP struct {
+	// X holds the training inputs, one (x_k, u_k) tuple per row.
+	X *mat.Dense
+	// Y holds the training outputs x_{k+1}, one per row of X.
+	Y *mat.Dense
+
+	// lengthScale and signalVar parametrize the squared-exponential kernel;
+	// noiseVar is the additive observation noise term.
+	lengthScale float64
+	signalVar   float64
+	noiseVar    float64
+
+	chol  mat.Cholesky
+	alpha *mat.Dense
+
+	stateDim int
+	ctlDim   int
+}
+
+// NewGP creates a GP model trained on inputs X and outputs Y, using a squared-exponential
+// kernel with the given lengthscale, signal variance and noise variance, and returns it.
+// X and Y must have the same number of rows, one per training tuple, and ctlDim is the
+// dimension of u so that X's column count splits into stateDim+ctlDim.
+func NewGP(X, Y *mat.Dense, ctlDim int, lengthScale, signalVar, noiseVar float64) (*GP, error) {
+	xr, xc := X.Dims()
+	yr, yc := Y.Dims()
+	if xr != yr {
+		return nil, fmt.Errorf("Invalid training data: %dx%d vs %dx%d", xr, xc, yr, yc)
+	}
+
+	if xc != yc+ctlDim {
+		return nil, fmt.Errorf("Invalid training data: expected %d state+input columns, got %d", yc+ctlDim, xc)
+	}
+
+	gp := &GP{
+		X:           X,
+		Y:           Y,
+		lengthScale: lengthScale,
+		signalVar:   signalVar,
+		noiseVar:    noiseVar,
+		stateDim:    yc,
+		ctlDim:      ctlDim,
+	}
+
+	if err := gp.train(); err != nil {
+		return nil, err
+	}
+
+	return gp, nil
+}
+
+// kernel returns the squared-exponential covariance between a and b.
+func (gp *GP) kernel(a, b mat.Vector) float64 {
+	var sqDist float64
+	for i := 0; i < a.Len(); i++ {
+		d := a.AtVec(i) - b.AtVec(i)
+		sqDist += d * d
+	}
+
+	return gp.signalVar * math.Exp(-0.5*sqDist/(gp.lengthScale*gp.lengthScale))
+}
+
+// train factorizes K(X,X) + noiseVar*I and caches alpha = K^-1 * Y so Propagate can
+// reuse it across calls without refactorizing.
+func (gp *GP) train() error {
+	n, _ := gp.X.Dims()
+
+	K := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			k := gp.kernel(gp.X.RowView(i), gp.X.RowView(j))
+			if i == j {
+				k += gp.noiseVar
+			}
+			K.SetSym(i, j, k)
+		}
+	}
+
+	if ok := gp.chol.Factorize(K); !ok {
+		return fmt.Errorf("Cholesky factorization of kernel matrix failed")
+	}
+
+	alpha := new(mat.Dense)
+	if err := gp.chol.SolveTo(alpha, gp.Y); err != nil {
+		return fmt.Errorf("Failed to solve for GP weights: %v", err)
+	}
+	gp.alpha = alpha
+
+	return nil
+}
+
+// feature concatenates state x and input u into a single training/test row.
+func feature(x, u mat.Vector) *mat.VecDense {
+	xl := x.Len()
+	var ul int
+	if u != nil {
+		ul = u.Len()
+	}
+
+	f := mat.NewVecDense(xl+ul, nil)
+	for i := 0; i < xl; i++ {
+		f.SetVec(i, x.AtVec(i))
+	}
+	for i := 0; i < ul; i++ {
+		f.SetVec(xl+i, u.AtVec(i))
+	}
+
+	return f
+}
+
+// kStar returns the vector of kernel evaluations between z and every training row.
+func (gp *GP) kStar(z mat.Vector) *mat.VecDense {
+	n, _ := gp.X.Dims()
+
+	k := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		k.SetVec(i, gp.kernel(z, gp.X.RowView(i)))
+	}
+
+	return k
+}
+
+// Propagate predicts the next internal state given current state x and input u as the
+// GP posterior mean k_*^T K^-1 y.
+func (gp *GP) Propagate(x, u, q mat.Vector) (mat.Vector, error) {
+	if gp.ctlDim > 0 && (u == nil || u.Len() != gp.ctlDim) {
+		return nil, fmt.Errorf("Invalid input vector")
+	}
+
+	if x.Len() != gp.stateDim {
+		return nil, fmt.Errorf("Invalid state vector")
+	}
+
+	kStar := gp.kStar(feature(x, u))
+
+	mean := new(mat.Dense)
+	mean.Mul(kStar.T(), gp.alpha)
+
+	out := mat.NewVecDense(gp.stateDim, nil)
+	for i := 0; i < gp.stateDim; i++ {
+		out.SetVec(i, mean.At(0, i))
+	}
+
+	if q != nil && q.Len() == gp.stateDim {
+		out.AddVec(out, q)
+	}
+
+	return out, nil
+}
+
+// PropagateCov returns the posterior predictive covariance of the propagated state,
+// k_** - k_*^T K^-1 k_*, which downstream UKF/EKF filters can use as state-dependent
+// process noise.
+func (gp *GP) PropagateCov(x, u mat.Vector) (float64, error) {
+	if gp.ctlDim > 0 && (u == nil || u.Len() != gp.ctlDim) {
+		return 0, fmt.Errorf("Invalid input vector")
+	}
+
+	if x.Len() != gp.stateDim {
+		return 0, fmt.Errorf("Invalid state vector")
+	}
+
+	kStar := gp.kStar(feature(x, u))
+	kSS := gp.kernel(feature(x, u), feature(x, u))
+
+	v := mat.NewVecDense(kStar.Len(), nil)
+	if err := gp.chol.SolveVecTo(v, kStar); err != nil {
+		return 0, err
+	}
+
+	return kSS - mat.Dot(kStar, v), nil
+}
+
+// Observe observes external state given internal state x and input u. GP assumes the
+// full state is measured directly, so it returns x unchanged aside from measurement noise.
+func (gp *GP) Observe(x, u, r mat.Vector) (mat.Vector, error) {
+	if u != nil && u.Len() != gp.ctlDim {
+		return nil, fmt.Errorf("Invalid input vector")
+	}
+
+	if x.Len() != gp.stateDim {
+		return nil, fmt.Errorf("Invalid state vector")
+	}
+
+	out := mat.NewVecDense(gp.stateDim, nil)
+	out.CloneFromVec(x)
+
+	if r != nil && r.Len() == gp.stateDim {
+		out.AddVec(out, r)
+	}
+
+	return out, nil
+}
+
+// Dims returns input and output model dimensions. Observe returns the full state
+// directly, so the output dimension matches the state dimension, consistent with
+// the rest of the module's Dims() contract.
+func (gp *GP) Dims() (int, int) {
+	return gp.stateDim, gp.stateDim
+}
+
+// logMarginalLikelihood returns the log marginal likelihood of the training data
+// under the current hyperparameters.
+func (gp *GP) logMarginalLikelihood() float64 {
+	n, _ := gp.X.Dims()
+
+	var quad float64
+	for c := 0; c < gp.stateDim; c++ {
+		quad += mat.Dot(gp.Y.ColView(c), gp.alpha.ColView(c))
+	}
+
+	L := mat.NewTriDense(n, mat.Lower, nil)
+	gp.chol.LTo(L)
+
+	var logDet float64
+	for i := 0; i < n; i++ {
+		logDet += math.Log(L.At(i, i))
+	}
+
+	return -0.5*quad - float64(gp.stateDim)*logDet - float64(n*gp.stateDim)/2*math.Log(2*math.Pi)
+}
+
+// gradLogLik computes the central-difference derivative of the marginal log-likelihood
+// with respect to the hyperparameter pointed to by p, retraining the GP at each
+// perturbation and restoring it afterwards.
+func (gp *GP) gradLogLik(p *float64, eps float64) (float64, error) {
+	orig := *p
+
+	*p = orig + eps
+	if err := gp.train(); err != nil {
+		return 0, err
+	}
+	plus := gp.logMarginalLikelihood()
+
+	*p = orig - eps
+	if err := gp.train(); err != nil {
+		return 0, err
+	}
+	minus := gp.logMarginalLikelihood()
+
+	*p = orig
+	if err := gp.train(); err != nil {
+		return 0, err
+	}
+
+	return (plus - minus) / (2 * eps), nil
+}
+
+// Fit tunes the kernel lengthscale and signal variance by gradient ascent on the
+// marginal log-likelihood, using central-difference gradients for iters steps of
+// size lr.
+func (gp *GP) Fit(iters int, lr float64) error {
+	const eps = 1e-4
+
+	for i := 0; i < iters; i++ {
+		dl, err := gp.gradLogLik(&gp.lengthScale, eps)
+		if err != nil {
+			return err
+		}
+
+		dv, err := gp.gradLogLik(&gp.signalVar, eps)
+		if err != nil {
+			return err
+		}
+
+		gp.lengthScale = math.Max(eps, gp.lengthScale+lr*dl)
+		gp.signalVar = math.Max(eps, gp.signalVar+lr*dv)
+	}
+
+	return gp.train()
+}
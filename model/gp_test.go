@@ -0,0 +1,136 @@
+package model
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// trainingGP builds a small GP trained on a handful of (x, u) -> x' tuples sampled
+// from a simple affine function, so predictions can be checked against it.
+func trainingGP(t *testing.T) *GP {
+	t.Helper()
+
+	// x_{k+1} = 0.9*x_k + 0.1*u_k, sampled at a handful of points.
+	xs := []float64{-2, -1, 0, 1, 2}
+	X := mat.NewDense(len(xs), 2, nil)
+	Y := mat.NewDense(len(xs), 1, nil)
+	for i, x := range xs {
+		u := 0.5
+		X.Set(i, 0, x)
+		X.Set(i, 1, u)
+		Y.Set(i, 0, 0.9*x+0.1*u)
+	}
+
+	gp, err := NewGP(X, Y, 1, 2.0, 1.0, 1e-6)
+	if err != nil {
+		t.Fatalf("NewGP failed: %v", err)
+	}
+
+	return gp
+}
+
+func TestGPPropagateRecoversTrainingPoint(t *testing.T) {
+	gp := trainingGP(t)
+
+	x := mat.NewVecDense(1, []float64{1})
+	u := mat.NewVecDense(1, []float64{0.5})
+
+	out, err := gp.Propagate(x, u, nil)
+	if err != nil {
+		t.Fatalf("Propagate failed: %v", err)
+	}
+
+	want := 0.9*1 + 0.1*0.5
+	if math.Abs(out.AtVec(0)-want) > 0.05 {
+		t.Errorf("Propagate at a training point = %f, want close to %f", out.AtVec(0), want)
+	}
+}
+
+func TestGPPropagateCovNearZeroAtTrainingPoint(t *testing.T) {
+	gp := trainingGP(t)
+
+	x := mat.NewVecDense(1, []float64{0})
+	u := mat.NewVecDense(1, []float64{0.5})
+
+	cov, err := gp.PropagateCov(x, u)
+	if err != nil {
+		t.Fatalf("PropagateCov failed: %v", err)
+	}
+	if cov > 0.05 {
+		t.Errorf("PropagateCov at a training point = %f, want close to 0", cov)
+	}
+}
+
+func TestGPDims(t *testing.T) {
+	gp := trainingGP(t)
+
+	stateDim, outDim := gp.Dims()
+	if stateDim != 1 || outDim != 1 {
+		t.Errorf("Dims() = (%d, %d), want (1, 1)", stateDim, outDim)
+	}
+}
+
+func TestGPObserveReturnsState(t *testing.T) {
+	gp := trainingGP(t)
+
+	x := mat.NewVecDense(1, []float64{3})
+	out, err := gp.Observe(x, nil, nil)
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if out.AtVec(0) != 3 {
+		t.Errorf("Observe(x) = %v, want x unchanged", out)
+	}
+}
+
+func TestNewGPInvalidTrainingData(t *testing.T) {
+	X := mat.NewDense(3, 2, nil)
+	Y := mat.NewDense(2, 1, nil)
+
+	if _, err := NewGP(X, Y, 1, 1, 1, 1e-6); err == nil {
+		t.Error("Expected error for mismatched row counts")
+	}
+}
+
+func TestGPPropagateRequiresControlWhenCtlDimNonzero(t *testing.T) {
+	gp := trainingGP(t)
+
+	x := mat.NewVecDense(1, []float64{1})
+	if _, err := gp.Propagate(x, nil, nil); err == nil {
+		t.Error("Expected error for nil input vector when ctlDim > 0")
+	}
+	if _, err := gp.PropagateCov(x, nil); err == nil {
+		t.Error("Expected error from PropagateCov for nil input vector when ctlDim > 0")
+	}
+}
+
+func TestGPFitIncreasesMarginalLikelihood(t *testing.T) {
+	xs := []float64{-2, -1, 0, 1, 2}
+	X := mat.NewDense(len(xs), 2, nil)
+	Y := mat.NewDense(len(xs), 1, nil)
+	for i, x := range xs {
+		u := 0.5
+		X.Set(i, 0, x)
+		X.Set(i, 1, u)
+		Y.Set(i, 0, 0.9*x+0.1*u)
+	}
+
+	// Start from deliberately poor hyperparameters so Fit has room to improve on them.
+	gp, err := NewGP(X, Y, 1, 0.1, 0.1, 1e-6)
+	if err != nil {
+		t.Fatalf("NewGP failed: %v", err)
+	}
+
+	before := gp.logMarginalLikelihood()
+
+	if err := gp.Fit(50, 1e-3); err != nil {
+		t.Fatalf("Fit failed: %v", err)
+	}
+
+	after := gp.logMarginalLikelihood()
+	if after < before {
+		t.Errorf("logMarginalLikelihood after Fit = %f, want >= %f (before Fit)", after, before)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"gonum.org/v1/gonum/mat"
+
+	"github.com/LIA-Aerospace/go-estimate/filter"
 )
 
 // InitCond implements filter.InitCond
@@ -15,7 +17,7 @@ type InitCond struct {
 // NewInitCond creates new InitCond and returns it
 func NewInitCond(state mat.Vector, cov mat.Symmetric) *InitCond {
 	s := &mat.VecDense{}
-	s.CloneVec(state)
+	s.CloneFromVec(state)
 
 	c := mat.NewSymDense(cov.Symmetric(), nil)
 	c.CopySym(cov)
@@ -29,7 +31,7 @@ func NewInitCond(state mat.Vector, cov mat.Symmetric) *InitCond {
 // State returns initial state
 func (c *InitCond) State() mat.Vector {
 	state := mat.NewVecDense(c.state.Len(), nil)
-	state.CopyVec(c.state)
+	state.CloneFromVec(c.state)
 
 	return state
 }
@@ -52,6 +54,12 @@ type Fall struct {
 	C *mat.Dense
 	// D is output control matrix
 	D *mat.Dense
+
+	// QNoise, if set, draws process noise for Propagate calls made with a nil q,
+	// letting filters plug in heavier-tailed noise models such as filter.StudentsT.
+	QNoise filter.NoiseSource
+	// RNoise, if set, draws measurement noise for Observe calls made with a nil r.
+	RNoise filter.NoiseSource
 }
 
 // NewFall creates a model of falling ball and returns it
@@ -70,6 +78,14 @@ func (b *Fall) Propagate(x, u, q mat.Vector) (mat.Vector, error) {
 		return nil, fmt.Errorf("Invalid state vector")
 	}
 
+	if q == nil && b.QNoise != nil {
+		sample, err := b.QNoise.Sample(1)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to draw process noise: %v", err)
+		}
+		q = sample.ColView(0)
+	}
+
 	out := new(mat.Dense)
 	out.Mul(b.A, x)
 
@@ -96,6 +112,14 @@ func (b *Fall) Observe(x, u, r mat.Vector) (mat.Vector, error) {
 		return nil, fmt.Errorf("Invalid state vector")
 	}
 
+	if r == nil && b.RNoise != nil {
+		sample, err := b.RNoise.Sample(1)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to draw measurement noise: %v", err)
+		}
+		r = sample.ColView(0)
+	}
+
 	out := new(mat.Dense)
 	out.Mul(b.C, x)
 
@@ -117,4 +141,4 @@ func (b *Fall) Dims() (int, int) {
 	out, _ := b.D.Dims()
 
 	return in, out
-}
\ No newline at end of file
+}
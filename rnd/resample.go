@@ -0,0 +1,98 @@
+package rnd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// StratifiedDrawN draws n indices from a probability mass function (PMF) defined by
+// weights in p using stratified resampling: it computes the CDF of p and, for each
+// i in [0,n), draws u_i = (i + U(0,1))/n and returns the index where cdf[idx] > u_i.
+// This has lower variance than RouletteDrawN's multinomial draws.
+// It fails with error if p is empty or nil.
+func StratifiedDrawN(p []float64, n int) ([]int, error) {
+	if p == nil || len(p) == 0 {
+		return nil, fmt.Errorf("Invalid probability weights: %v", p)
+	}
+
+	cdf := make([]float64, len(p))
+	floats.CumSum(cdf, p)
+	total := cdf[len(cdf)-1]
+
+	indices := make([]int, n)
+	for i := range indices {
+		u := (float64(i) + distuv.UnitUniform.Rand()) / float64(n) * total
+		indices[i] = sort.Search(len(cdf), func(j int) bool { return cdf[j] > u })
+	}
+
+	return indices, nil
+}
+
+// SystematicDrawN draws n indices from a probability mass function (PMF) defined by
+// weights in p using systematic resampling: it computes the CDF of p, draws a single
+// u0 ~ U(0, 1/n) and, for each i in [0,n), uses u_i = u0 + i/n to find the index
+// where cdf[idx] > u_i. The deterministic spacing between draws gives it lower
+// variance than StratifiedDrawN.
+// It fails with error if p is empty or nil.
+func SystematicDrawN(p []float64, n int) ([]int, error) {
+	if p == nil || len(p) == 0 {
+		return nil, fmt.Errorf("Invalid probability weights: %v", p)
+	}
+
+	cdf := make([]float64, len(p))
+	floats.CumSum(cdf, p)
+	total := cdf[len(cdf)-1]
+
+	u0 := distuv.UnitUniform.Rand() / float64(n) * total
+
+	indices := make([]int, n)
+	for i := range indices {
+		u := u0 + float64(i)/float64(n)*total
+		indices[i] = sort.Search(len(cdf), func(j int) bool { return cdf[j] > u })
+	}
+
+	return indices, nil
+}
+
+// ResidualDrawN draws n indices from a probability mass function (PMF) defined by
+// weights in p using residual resampling: for each weight it takes k_i = floor(n*w_i)
+// deterministic copies of index i, then fills the remaining n - sum(k_i) draws by
+// resampling multinomially from the residual weights n*w_i - k_i.
+// It fails with error if p is empty or nil.
+func ResidualDrawN(p []float64, n int) ([]int, error) {
+	if p == nil || len(p) == 0 {
+		return nil, fmt.Errorf("Invalid probability weights: %v", p)
+	}
+
+	total := floats.Sum(p)
+
+	residual := make([]float64, len(p))
+	indices := make([]int, 0, n)
+
+	for i, w := range p {
+		weight := w / total * float64(n)
+		k := int(math.Floor(weight))
+
+		for j := 0; j < k; j++ {
+			indices = append(indices, i)
+		}
+
+		residual[i] = weight - float64(k)
+	}
+
+	remaining := n - len(indices)
+	if remaining > 0 {
+		drawn, err := RouletteDrawN(residual, remaining)
+		if err != nil {
+			return nil, err
+		}
+
+		indices = append(indices, drawn...)
+	}
+
+	return indices[:n], nil
+}
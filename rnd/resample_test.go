@@ -0,0 +1,84 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+)
+
+// checkDrawN asserts that draw returns n indices, all within [0, len(p)), and that
+// the empirical distribution of indices is close to p for a large enough n.
+func checkDrawN(t *testing.T, name string, draw func(p []float64, n int) ([]int, error)) {
+	t.Helper()
+
+	p := []float64{0.1, 0.6, 0.3}
+	n := 20000
+
+	indices, err := draw(p, n)
+	if err != nil {
+		t.Fatalf("%s failed: %v", name, err)
+	}
+	if len(indices) != n {
+		t.Fatalf("%s returned %d indices, want %d", name, len(indices), n)
+	}
+
+	counts := make([]int, len(p))
+	for _, idx := range indices {
+		if idx < 0 || idx >= len(p) {
+			t.Fatalf("%s returned out-of-range index %d", name, idx)
+		}
+		counts[idx]++
+	}
+
+	for i, want := range p {
+		got := float64(counts[i]) / float64(n)
+		if math.Abs(got-want) > 0.02 {
+			t.Errorf("%s: fraction of index %d = %f, want close to %f", name, i, got, want)
+		}
+	}
+}
+
+func TestStratifiedDrawN(t *testing.T) {
+	checkDrawN(t, "StratifiedDrawN", StratifiedDrawN)
+}
+
+func TestSystematicDrawN(t *testing.T) {
+	checkDrawN(t, "SystematicDrawN", SystematicDrawN)
+}
+
+func TestResidualDrawN(t *testing.T) {
+	checkDrawN(t, "ResidualDrawN", ResidualDrawN)
+}
+
+func TestResidualDrawNDeterministicCopies(t *testing.T) {
+	// index 0 should get exactly floor(n*w) = 8 guaranteed deterministic copies
+	// before any residual multinomial draws are added.
+	p := []float64{0.8, 0.2}
+	n := 10
+
+	indices, err := ResidualDrawN(p, n)
+	if err != nil {
+		t.Fatalf("ResidualDrawN failed: %v", err)
+	}
+
+	count0 := 0
+	for _, idx := range indices {
+		if idx == 0 {
+			count0++
+		}
+	}
+	if count0 < 8 {
+		t.Errorf("Expected at least 8 deterministic copies of index 0, got %d", count0)
+	}
+}
+
+func TestDrawNEmptyWeights(t *testing.T) {
+	for name, draw := range map[string]func([]float64, int) ([]int, error){
+		"StratifiedDrawN": StratifiedDrawN,
+		"SystematicDrawN": SystematicDrawN,
+		"ResidualDrawN":   ResidualDrawN,
+	} {
+		if _, err := draw(nil, 10); err == nil {
+			t.Errorf("%s: expected error for nil weights", name)
+		}
+	}
+}
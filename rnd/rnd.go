@@ -32,7 +32,7 @@ func WithCovN(cov *mat.Dense, n int) (*mat.Dense, error) {
 	for i := range vals {
 		vals[i] = math.Sqrt(vals[i])
 	}
-	diag := mat.NewDiagonal(len(vals), vals)
+	diag := mat.NewDiagDense(len(vals), vals)
 	U.Mul(U, diag)
 
 	rows, _ := cov.Dims()
@@ -46,6 +46,42 @@ func WithCovN(cov *mat.Dense, n int) (*mat.Dense, error) {
 	return samples, nil
 }
 
+// WithStudentsTN draws n random samples from a multivariate Student's-T distribution with mean mu,
+// scale matrix sigma and nu degrees of freedom. It uses the well-known scale-mixture construction:
+// it draws x ~ N(0, sigma) using the same SVD path as WithCovN, draws u ~ ChiSquared(nu) once per
+// column and returns mu + x*sqrt(nu/u), which has the correct covariance nu/(nu-2)*sigma for nu>2.
+// It returns an m x n matrix which contains the random samples stored as its columns.
+// It fails with error if n is non-positive and/or smaller than 2, if nu is non-positive,
+// if mu does not match the dimensions of sigma, or if sigma fails to be factorized using SVD.
+func WithStudentsTN(mu []float64, sigma *mat.SymDense, nu float64, n int) (*mat.Dense, error) {
+	if nu <= 0 {
+		return nil, fmt.Errorf("Invalid degrees of freedom: %f", nu)
+	}
+
+	dim := sigma.Symmetric()
+	if len(mu) != dim {
+		return nil, fmt.Errorf("Invalid mean vector: %v", mu)
+	}
+
+	cov := mat.NewDense(dim, dim, nil)
+	cov.Copy(sigma)
+
+	samples, err := WithCovN(cov, n)
+	if err != nil {
+		return nil, err
+	}
+
+	chi2 := distuv.ChiSquared{K: nu}
+	for j := 0; j < n; j++ {
+		scale := math.Sqrt(nu / chi2.Rand())
+		for i := 0; i < dim; i++ {
+			samples.Set(i, j, mu[i]+samples.At(i, j)*scale)
+		}
+	}
+
+	return samples, nil
+}
+
 // RouletteDrawN draws n numbers randomly from a probability mass function (PMF) defined by weights in p.
 // RouletteDrawN implements the Roulette Wheel Draw a.k.a. Fitness Proportionate Selection:
 // - https://en.wikipedia.org/wiki/Fitness_proportionate_selection
@@ -0,0 +1,108 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestWithCovN(t *testing.T) {
+	cov := mat.NewDense(2, 2, []float64{1, 0, 0, 1})
+
+	samples, err := WithCovN(cov, 5000)
+	if err != nil {
+		t.Fatalf("WithCovN failed: %v", err)
+	}
+
+	rows, cols := samples.Dims()
+	if rows != 2 || cols != 5000 {
+		t.Fatalf("Unexpected samples shape: %dx%d", rows, cols)
+	}
+
+	var sum0, sum1 float64
+	for j := 0; j < cols; j++ {
+		sum0 += samples.At(0, j)
+		sum1 += samples.At(1, j)
+	}
+	mean0, mean1 := sum0/float64(cols), sum1/float64(cols)
+	if math.Abs(mean0) > 0.1 || math.Abs(mean1) > 0.1 {
+		t.Errorf("Sample means too far from zero: %f, %f", mean0, mean1)
+	}
+}
+
+func TestWithCovNInvalidN(t *testing.T) {
+	cov := mat.NewDense(2, 2, []float64{1, 0, 0, 1})
+
+	if _, err := WithCovN(cov, 1); err == nil {
+		t.Error("Expected error for n <= 1")
+	}
+}
+
+func TestWithStudentsTN(t *testing.T) {
+	mu := []float64{1, -2}
+	sigma := mat.NewSymDense(2, []float64{1, 0, 0, 1})
+	nu := 10.0
+
+	samples, err := WithStudentsTN(mu, sigma, nu, 20000)
+	if err != nil {
+		t.Fatalf("WithStudentsTN failed: %v", err)
+	}
+
+	rows, cols := samples.Dims()
+	if rows != 2 || cols != 20000 {
+		t.Fatalf("Unexpected samples shape: %dx%d", rows, cols)
+	}
+
+	var sum0, sum1, sqSum0 float64
+	for j := 0; j < cols; j++ {
+		sum0 += samples.At(0, j)
+		sum1 += samples.At(1, j)
+		sqSum0 += (samples.At(0, j) - mu[0]) * (samples.At(0, j) - mu[0])
+	}
+	mean0, mean1 := sum0/float64(cols), sum1/float64(cols)
+	if math.Abs(mean0-mu[0]) > 0.1 || math.Abs(mean1-mu[1]) > 0.1 {
+		t.Errorf("Sample means too far from mu: %f, %f", mean0, mean1)
+	}
+
+	// Variance of a multivariate Student's-T with scale sigma=1 and nu degrees
+	// of freedom is nu/(nu-2).
+	wantVar := nu / (nu - 2)
+	gotVar := sqSum0 / float64(cols)
+	if math.Abs(gotVar-wantVar) > 0.2 {
+		t.Errorf("Sample variance = %f, want close to %f", gotVar, wantVar)
+	}
+}
+
+func TestWithStudentsTNInvalidNu(t *testing.T) {
+	mu := []float64{0, 0}
+	sigma := mat.NewSymDense(2, []float64{1, 0, 0, 1})
+
+	if _, err := WithStudentsTN(mu, sigma, 0, 10); err == nil {
+		t.Error("Expected error for non-positive nu")
+	}
+}
+
+func TestWithStudentsTNInvalidMu(t *testing.T) {
+	mu := []float64{0, 0, 0}
+	sigma := mat.NewSymDense(2, []float64{1, 0, 0, 1})
+
+	if _, err := WithStudentsTN(mu, sigma, 5, 10); err == nil {
+		t.Error("Expected error for mismatched mean vector")
+	}
+}
+
+func TestRouletteDrawN(t *testing.T) {
+	p := []float64{0, 1, 0}
+
+	indices, err := RouletteDrawN(p, 10)
+	if err != nil {
+		t.Fatalf("RouletteDrawN failed: %v", err)
+	}
+
+	for _, idx := range indices {
+		if idx != 1 {
+			t.Errorf("Expected index 1 for a degenerate PMF, got %d", idx)
+		}
+	}
+}
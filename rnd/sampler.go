@@ -0,0 +1,134 @@
+package rnd
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Sampler draws repeated samples from a zero-mean multivariate Gaussian distribution
+// with a fixed covariance, caching its factorization so that repeated draws - e.g.
+// resampling particles thousands of times per filter step - do not pay the
+// factorization cost more than once.
+type Sampler struct {
+	dim int
+
+	// chol is set when cov is well-conditioned; computing samples from it is
+	// roughly 3-10x cheaper than the SVD path WithCovN always uses.
+	chol *mat.Cholesky
+
+	// transform is applied to standard-normal draws to give them the
+	// requested covariance: samples = transform * z.
+	transform *mat.Dense
+
+	// u and vals back the SVD fallback's Mahalanobis distance computation in
+	// LogProb; they are nil unless NewSampler fell back to SVD, i.e. the Cholesky
+	// factorization failed or cov was near-singular.
+	u    *mat.Dense
+	vals []float64
+
+	logSqrtDet float64
+}
+
+// NewSampler creates a Sampler for the given covariance. It attempts to factorize cov
+// with Cholesky decomposition first and only falls back to SVD - which WithCovN always
+// uses - if the factorization fails or cov is near-singular.
+func NewSampler(cov *mat.SymDense) (*Sampler, error) {
+	dim := cov.Symmetric()
+	s := &Sampler{dim: dim}
+
+	var chol mat.Cholesky
+	if ok := chol.Factorize(cov); ok && chol.Cond() < 1e12 {
+		s.chol = &chol
+
+		L := mat.NewTriDense(dim, mat.Lower, nil)
+		chol.LTo(L)
+
+		transform := new(mat.Dense)
+		transform.CloneFrom(L)
+		s.transform = transform
+
+		s.logSqrtDet = 0.5 * chol.LogDet()
+
+		return s, nil
+	}
+
+	covDense := mat.NewDense(dim, dim, nil)
+	covDense.Copy(cov)
+
+	var svd mat.SVD
+	if !svd.Factorize(covDense, mat.SVDFull) {
+		return nil, fmt.Errorf("SVD factorization failed")
+	}
+
+	U := new(mat.Dense)
+	svd.UTo(U)
+	vals := svd.Values(nil)
+
+	sqrtVals := make([]float64, len(vals))
+	logDet := 0.0
+	for i, v := range vals {
+		logDet += math.Log(v)
+		sqrtVals[i] = math.Sqrt(v)
+	}
+
+	diag := mat.NewDiagDense(len(sqrtVals), sqrtVals)
+	transform := new(mat.Dense)
+	transform.Mul(U, diag)
+
+	s.u = U
+	s.vals = vals
+	s.transform = transform
+	s.logSqrtDet = 0.5 * logDet
+
+	return s, nil
+}
+
+// SampleTo draws a sample for each column of dst, overwriting its contents. Reusing
+// dst and the Sampler's cached factorization avoids refactorizing cov on every draw;
+// a buffer of standard-normal draws the size of dst is still allocated per call.
+func (s *Sampler) SampleTo(dst *mat.Dense) error {
+	rows, cols := dst.Dims()
+	if rows != s.dim {
+		return fmt.Errorf("Invalid destination matrix: %vx%v", rows, cols)
+	}
+
+	data := make([]float64, rows*cols)
+	for i := range data {
+		data[i] = rand.NormFloat64()
+	}
+	z := mat.NewDense(rows, cols, data)
+
+	dst.Mul(s.transform, z)
+
+	return nil
+}
+
+// LogProb returns the log probability density of x under the zero-mean Gaussian
+// distribution backing the Sampler, using the cached logSqrtDet and a triangular
+// solve, matching the pattern established by gonum's distmv.Normal. When NewSampler
+// fell back to SVD, the cached SVD is used in place of the triangular solve.
+func (s *Sampler) LogProb(x mat.Vector) float64 {
+	y := mat.NewVecDense(s.dim, nil)
+
+	if s.chol != nil {
+		if err := s.chol.SolveVecTo(y, x); err != nil {
+			return math.Inf(-1)
+		}
+	} else {
+		// Sigma^-1 = U * diag(1/vals) * U^T from the cached SVD. Every singular value
+		// is inverted, so a zero one makes the result non-finite.
+		t := mat.NewVecDense(s.dim, nil)
+		t.MulVec(s.u.T(), x)
+		for i := 0; i < s.dim; i++ {
+			t.SetVec(i, t.AtVec(i)/s.vals[i])
+		}
+		y.MulVec(s.u, t)
+	}
+
+	maha := mat.Dot(x, y)
+
+	return -0.5*maha - s.logSqrtDet - float64(s.dim)/2*math.Log(2*math.Pi)
+}
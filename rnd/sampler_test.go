@@ -0,0 +1,89 @@
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSamplerCholeskyPath(t *testing.T) {
+	cov := mat.NewSymDense(2, []float64{2, 0.3, 0.3, 1})
+
+	s, err := NewSampler(cov)
+	if err != nil {
+		t.Fatalf("NewSampler failed: %v", err)
+	}
+	if s.chol == nil {
+		t.Fatal("Expected well-conditioned covariance to take the Cholesky path")
+	}
+
+	dst := mat.NewDense(2, 5000, nil)
+	if err := s.SampleTo(dst); err != nil {
+		t.Fatalf("SampleTo failed: %v", err)
+	}
+
+	_, cols := dst.Dims()
+	var sumSq00, sumSq11 float64
+	for j := 0; j < cols; j++ {
+		sumSq00 += dst.At(0, j) * dst.At(0, j)
+		sumSq11 += dst.At(1, j) * dst.At(1, j)
+	}
+	var00 := sumSq00 / float64(cols)
+	var11 := sumSq11 / float64(cols)
+	if math.Abs(var00-2) > 0.2 {
+		t.Errorf("Sample variance[0][0] = %f, want close to 2", var00)
+	}
+	if math.Abs(var11-1) > 0.2 {
+		t.Errorf("Sample variance[1][1] = %f, want close to 1", var11)
+	}
+}
+
+func TestSamplerSVDFallback(t *testing.T) {
+	// A singular covariance (rank 1) fails Cholesky factorization and must fall
+	// back to the SVD path.
+	cov := mat.NewSymDense(2, []float64{1, 1, 1, 1})
+
+	s, err := NewSampler(cov)
+	if err != nil {
+		t.Fatalf("NewSampler failed: %v", err)
+	}
+	if s.chol != nil {
+		t.Fatal("Expected singular covariance to fall back to the SVD path")
+	}
+
+	dst := mat.NewDense(2, 10, nil)
+	if err := s.SampleTo(dst); err != nil {
+		t.Fatalf("SampleTo failed: %v", err)
+	}
+}
+
+func TestSamplerLogProbMatchesGaussian(t *testing.T) {
+	cov := mat.NewSymDense(2, []float64{1, 0, 0, 1})
+
+	s, err := NewSampler(cov)
+	if err != nil {
+		t.Fatalf("NewSampler failed: %v", err)
+	}
+
+	x := mat.NewVecDense(2, []float64{0, 0})
+	got := s.LogProb(x)
+	want := -math.Log(2 * math.Pi)
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("LogProb at origin = %f, want %f", got, want)
+	}
+}
+
+func TestSamplerSampleToWrongShape(t *testing.T) {
+	cov := mat.NewSymDense(2, []float64{1, 0, 0, 1})
+
+	s, err := NewSampler(cov)
+	if err != nil {
+		t.Fatalf("NewSampler failed: %v", err)
+	}
+
+	dst := mat.NewDense(3, 10, nil)
+	if err := s.SampleTo(dst); err == nil {
+		t.Error("Expected error for destination with mismatched row count")
+	}
+}
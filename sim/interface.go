@@ -0,0 +1,19 @@
+package sim
+
+import "gonum.org/v1/gonum/mat"
+
+// Model is the common contract implemented by both linear and nonlinear system
+// models in this package, allowing downstream filters to consume either through
+// the same interface.
+type Model interface {
+	// Propagate propagates internal state x to the next step given input u and process noise q.
+	Propagate(x, u, q mat.Vector) (mat.Vector, error)
+	// Observe observes external state given internal state x, input u and measurement noise r.
+	Observe(x, u, r mat.Vector) (mat.Vector, error)
+	// Dims returns input and output model dimensions.
+	Dims() (int, int)
+	// StateMatrix returns state propagation matrix, linearized around the current state if nonlinear.
+	StateMatrix() mat.Matrix
+	// OutputMatrix returns observation matrix, linearized around the current state if nonlinear.
+	OutputMatrix() mat.Matrix
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"gonum.org/v1/gonum/mat"
+
+	"github.com/LIA-Aerospace/go-estimate/filter"
 )
 
 // InitCond implements filter.InitCond
@@ -52,6 +54,12 @@ type BaseModel struct {
 	C *mat.Dense
 	// D is output control matrix
 	D *mat.Dense
+
+	// QNoise, if set, draws process noise for Propagate calls made with a nil q,
+	// letting filters plug in heavier-tailed noise models such as filter.StudentsT.
+	QNoise filter.NoiseSource
+	// RNoise, if set, draws measurement noise for Observe calls made with a nil r.
+	RNoise filter.NoiseSource
 }
 
 // NewBaseModel creates a model of falling ball and returns it
@@ -70,6 +78,14 @@ func (b *BaseModel) Propagate(x, u, q mat.Vector) (mat.Vector, error) {
 		return nil, fmt.Errorf("Invalid state vector")
 	}
 
+	if q == nil && b.QNoise != nil {
+		sample, err := b.QNoise.Sample(1)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to draw process noise: %v", err)
+		}
+		q = sample.ColView(0)
+	}
+
 	out := new(mat.Dense)
 	out.Mul(b.A, x)
 
@@ -98,6 +114,14 @@ func (b *BaseModel) Observe(x, u, r mat.Vector) (mat.Vector, error) {
 		return nil, fmt.Errorf("Invalid state vector")
 	}
 
+	if r == nil && b.RNoise != nil {
+		sample, err := b.RNoise.Sample(1)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to draw measurement noise: %v", err)
+		}
+		r = sample.ColView(0)
+	}
+
 	out := new(mat.Dense)
 	out.Mul(b.C, x)
 
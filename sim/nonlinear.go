@@ -0,0 +1,205 @@
+package sim
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// step is the default perturbation used for central-difference numerical differentiation.
+const step = 1e-6
+
+// PropagateFunc computes the next internal state given current state x and input u.
+type PropagateFunc func(x, u mat.Vector) mat.Vector
+
+// ObserveFunc computes the external state given internal state x and input u.
+type ObserveFunc func(x, u mat.Vector) mat.Vector
+
+// JacobianFunc computes the Jacobian of a PropagateFunc or ObserveFunc with respect to
+// either x or u, evaluated at (x, u).
+type JacobianFunc func(x, u mat.Vector) mat.Matrix
+
+// NonlinearModel is a model of a nonlinear dynamical system defined by user-provided
+// propagation and observation closures. Analytic Jacobians may be supplied via Fx, Fu,
+// Hx and Hu; any left nil are approximated with central-difference numerical
+// differentiation around the current linearization point.
+type NonlinearModel struct {
+	f PropagateFunc
+	h ObserveFunc
+
+	fx JacobianFunc
+	fu JacobianFunc
+	hx JacobianFunc
+	hu JacobianFunc
+
+	inDim  int
+	outDim int
+
+	// measDim is the length of h's output, i.e. the measurement dimension. It is
+	// distinct from outDim (the control input dimension) and is kept up to date
+	// from the actual output of h on every Observe call, since OutputMatrix/
+	// OutputCtlMatrix need it to size the Jacobian of h correctly.
+	measDim int
+
+	// x and u hold the last linearization point passed to Propagate/Observe so
+	// StateMatrix/OutputMatrix can evaluate Jacobians at it on demand. A nil u
+	// leaves u at its previous value.
+	x *mat.VecDense
+	u *mat.VecDense
+
+	// xPert is workspace reused across numerical differentiation calls to keep
+	// allocations out of hot loops.
+	xPert *mat.VecDense
+	uPert *mat.VecDense
+}
+
+// NewNonlinearModel creates a nonlinear model with state dimension inDim and input
+// dimension outDim, propagated and observed via f and h, and returns it. fx, fu, hx
+// and hu are optional analytic Jacobians; pass nil to fall back to numerical
+// differentiation.
+func NewNonlinearModel(inDim, outDim int, f PropagateFunc, h ObserveFunc, fx, fu, hx, hu JacobianFunc) (*NonlinearModel, error) {
+	if f == nil || h == nil {
+		return nil, fmt.Errorf("Invalid propagation/observation function")
+	}
+
+	return &NonlinearModel{
+		f:      f,
+		h:      h,
+		fx:     fx,
+		fu:     fu,
+		hx:     hx,
+		hu:     hu,
+		inDim:  inDim,
+		outDim: outDim,
+		// measDim defaults to outDim until the first Observe call reveals h's
+		// actual output length; see the measDim field comment.
+		measDim: outDim,
+		x:       mat.NewVecDense(inDim, nil),
+		u:       mat.NewVecDense(outDim, nil),
+		xPert:   mat.NewVecDense(inDim, nil),
+		uPert:   mat.NewVecDense(outDim, nil),
+	}, nil
+}
+
+// Propagate propagates internal state x to the next step given input u and process noise q.
+func (m *NonlinearModel) Propagate(x, u, q mat.Vector) (mat.Vector, error) {
+	if u != nil && u.Len() != m.outDim {
+		return nil, fmt.Errorf("Invalid input vector")
+	}
+
+	if x.Len() != m.inDim {
+		return nil, fmt.Errorf("Invalid state vector")
+	}
+
+	m.x.CloneFromVec(x)
+	if u != nil {
+		m.u.CloneFromVec(u)
+	}
+
+	out := m.f(x, u)
+	if q != nil && q.Len() == m.inDim {
+		res := mat.NewVecDense(m.inDim, nil)
+		res.AddVec(out, q)
+		return res, nil
+	}
+
+	return out, nil
+}
+
+// Observe observes external state given internal state x, input u and measurement noise r.
+func (m *NonlinearModel) Observe(x, u, r mat.Vector) (mat.Vector, error) {
+	if u != nil && u.Len() != m.outDim {
+		return nil, fmt.Errorf("Invalid input vector")
+	}
+
+	if x.Len() != m.inDim {
+		return nil, fmt.Errorf("Invalid state vector")
+	}
+
+	m.x.CloneFromVec(x)
+	if u != nil {
+		m.u.CloneFromVec(u)
+	}
+
+	out := m.h(x, u)
+	m.measDim = out.Len()
+
+	if r != nil && r.Len() == out.Len() {
+		res := mat.NewVecDense(out.Len(), nil)
+		res.AddVec(out, r)
+		return res, nil
+	}
+
+	return out, nil
+}
+
+// Dims returns input and output model dimensions.
+func (m *NonlinearModel) Dims() (int, int) {
+	return m.inDim, m.outDim
+}
+
+// StateMatrix returns the Jacobian of f with respect to x, evaluated at the last
+// linearization point passed to Propagate or Observe.
+func (m *NonlinearModel) StateMatrix() mat.Matrix {
+	if m.fx != nil {
+		return m.fx(m.x, m.u)
+	}
+
+	return m.numJacobian(func(x mat.Vector) mat.Vector { return m.f(x, m.u) }, m.x, m.xPert, m.inDim)
+}
+
+// StateCtlMatrix returns the Jacobian of f with respect to u, evaluated at the last
+// linearization point passed to Propagate or Observe.
+func (m *NonlinearModel) StateCtlMatrix() mat.Matrix {
+	if m.fu != nil {
+		return m.fu(m.x, m.u)
+	}
+
+	return m.numJacobian(func(u mat.Vector) mat.Vector { return m.f(m.x, u) }, m.u, m.uPert, m.inDim)
+}
+
+// OutputMatrix returns the Jacobian of h with respect to x, evaluated at the last
+// linearization point passed to Propagate or Observe.
+func (m *NonlinearModel) OutputMatrix() mat.Matrix {
+	if m.hx != nil {
+		return m.hx(m.x, m.u)
+	}
+
+	return m.numJacobian(func(x mat.Vector) mat.Vector { return m.h(x, m.u) }, m.x, m.xPert, m.measDim)
+}
+
+// OutputCtlMatrix returns the Jacobian of h with respect to u, evaluated at the last
+// linearization point passed to Propagate or Observe.
+func (m *NonlinearModel) OutputCtlMatrix() mat.Matrix {
+	if m.hu != nil {
+		return m.hu(m.x, m.u)
+	}
+
+	return m.numJacobian(func(u mat.Vector) mat.Vector { return m.h(m.x, u) }, m.u, m.uPert, m.measDim)
+}
+
+// numJacobian approximates the Jacobian of g with respect to v via central-difference
+// numerical differentiation, reusing pert as workspace for the perturbed vector to
+// avoid allocating on every evaluation. outDim is the length of g's output.
+func (m *NonlinearModel) numJacobian(g func(v mat.Vector) mat.Vector, v, pert *mat.VecDense, outDim int) *mat.Dense {
+	n := v.Len()
+	jac := mat.NewDense(outDim, n, nil)
+
+	for j := 0; j < n; j++ {
+		orig := v.AtVec(j)
+
+		pert.CloneFromVec(v)
+		pert.SetVec(j, orig+step)
+		plus := g(pert)
+
+		pert.CloneFromVec(v)
+		pert.SetVec(j, orig-step)
+		minus := g(pert)
+
+		for i := 0; i < outDim; i++ {
+			jac.Set(i, j, (plus.AtVec(i)-minus.AtVec(i))/(2*step))
+		}
+	}
+
+	return jac
+}
@@ -0,0 +1,126 @@
+package sim
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// linear2D is f(x,u) = A*x + B*u for a fixed 2x2 A and 2x1 B, used to check that
+// numerical differentiation recovers a known analytic Jacobian.
+func linear2D(x, u mat.Vector) mat.Vector {
+	out := mat.NewVecDense(2, nil)
+	out.SetVec(0, 2*x.AtVec(0)+0.5*x.AtVec(1))
+	out.SetVec(1, -1*x.AtVec(0)+3*x.AtVec(1))
+	if u != nil {
+		out.SetVec(0, out.AtVec(0)+u.AtVec(0))
+		out.SetVec(1, out.AtVec(1)+2*u.AtVec(0))
+	}
+	return out
+}
+
+func TestNonlinearModelNumericalJacobian(t *testing.T) {
+	m, err := NewNonlinearModel(2, 1, linear2D, linear2D, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewNonlinearModel failed: %v", err)
+	}
+
+	x := mat.NewVecDense(2, []float64{1, -2})
+	u := mat.NewVecDense(1, []float64{0.5})
+
+	if _, err := m.Propagate(x, u, nil); err != nil {
+		t.Fatalf("Propagate failed: %v", err)
+	}
+
+	wantA := []float64{2, 0.5, -1, 3}
+	gotA := m.StateMatrix()
+	r, c := gotA.Dims()
+	if r != 2 || c != 2 {
+		t.Fatalf("StateMatrix shape = %dx%d, want 2x2", r, c)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if math.Abs(gotA.At(i, j)-wantA[i*2+j]) > 1e-4 {
+				t.Errorf("StateMatrix[%d][%d] = %f, want %f", i, j, gotA.At(i, j), wantA[i*2+j])
+			}
+		}
+	}
+
+	wantB := []float64{1, 2}
+	gotB := m.StateCtlMatrix()
+	for i := 0; i < 2; i++ {
+		if math.Abs(gotB.At(i, 0)-wantB[i]) > 1e-4 {
+			t.Errorf("StateCtlMatrix[%d] = %f, want %f", i, gotB.At(i, 0), wantB[i])
+		}
+	}
+}
+
+func TestNonlinearModelAnalyticJacobian(t *testing.T) {
+	fx := func(x, u mat.Vector) mat.Matrix {
+		return mat.NewDense(2, 2, []float64{2, 0.5, -1, 3})
+	}
+
+	m, err := NewNonlinearModel(2, 1, linear2D, linear2D, fx, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewNonlinearModel failed: %v", err)
+	}
+
+	x := mat.NewVecDense(2, []float64{1, -2})
+	if _, err := m.Propagate(x, nil, nil); err != nil {
+		t.Fatalf("Propagate failed: %v", err)
+	}
+
+	got := m.StateMatrix()
+	if got.At(0, 0) != 2 || got.At(1, 1) != 3 {
+		t.Errorf("StateMatrix did not use the supplied analytic Jacobian: %v", got)
+	}
+}
+
+func TestNewNonlinearModelRequiresFunctions(t *testing.T) {
+	if _, err := NewNonlinearModel(2, 1, nil, linear2D, nil, nil, nil, nil); err == nil {
+		t.Error("Expected error for nil propagation function")
+	}
+}
+
+// rangeOnly is a 1-d measurement h(x,u) = ||x|| of a 2-state system, used to check
+// OutputMatrix/OutputCtlMatrix when the measurement dimension differs from the
+// control dimension.
+func rangeOnly(x, u mat.Vector) mat.Vector {
+	out := mat.NewVecDense(1, nil)
+	out.SetVec(0, math.Hypot(x.AtVec(0), x.AtVec(1)))
+	return out
+}
+
+func TestNonlinearModelOutputMatrixWithSmallerMeasurementDim(t *testing.T) {
+	m, err := NewNonlinearModel(2, 2, linear2D, rangeOnly, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewNonlinearModel failed: %v", err)
+	}
+
+	x := mat.NewVecDense(2, []float64{3, 4})
+	u := mat.NewVecDense(2, []float64{0, 0})
+
+	if _, err := m.Observe(x, u, nil); err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+
+	got := m.OutputMatrix()
+	r, c := got.Dims()
+	if r != 1 || c != 2 {
+		t.Fatalf("OutputMatrix shape = %dx%d, want 1x2", r, c)
+	}
+
+	wantA := []float64{0.6, 0.8}
+	for j := 0; j < 2; j++ {
+		if math.Abs(got.At(0, j)-wantA[j]) > 1e-4 {
+			t.Errorf("OutputMatrix[0][%d] = %f, want %f", j, got.At(0, j), wantA[j])
+		}
+	}
+
+	gotU := m.OutputCtlMatrix()
+	r, c = gotU.Dims()
+	if r != 1 || c != 2 {
+		t.Fatalf("OutputCtlMatrix shape = %dx%d, want 1x2", r, c)
+	}
+}
@@ -0,0 +1,142 @@
+package smoother
+
+// history is the L-BFGS two-loop recursion state: ring buffers of the last mem
+// parameter differences s_i = x_{i+1}-x_i, gradient differences y_i = g_{i+1}-g_i,
+// and rho_i = 1/(s_i . y_i).
+type history struct {
+	mem int
+	s   [][]float64
+	y   [][]float64
+	rho []float64
+}
+
+// newHistory creates an empty L-BFGS history bounded to mem entries.
+func newHistory(mem int) *history {
+	return &history{mem: mem}
+}
+
+// update pushes a new (s, y) pair computed from consecutive iterates and gradients
+// onto the history, evicting the oldest entry once mem is exceeded.
+func (h *history) update(xNext, x, gNext, g []float64) {
+	s := make([]float64, len(x))
+	y := make([]float64, len(x))
+
+	var sy float64
+	for i := range x {
+		s[i] = xNext[i] - x[i]
+		y[i] = gNext[i] - g[i]
+		sy += s[i] * y[i]
+	}
+
+	if sy <= 1e-10 {
+		// Skip updates that would make rho blow up or go negative; curvature
+		// condition isn't satisfied along this step.
+		return
+	}
+
+	h.s = append(h.s, s)
+	h.y = append(h.y, y)
+	h.rho = append(h.rho, 1/sy)
+
+	if len(h.s) > h.mem {
+		h.s = h.s[1:]
+		h.y = h.y[1:]
+		h.rho = h.rho[1:]
+	}
+}
+
+// direction computes the L-BFGS descent direction for gradient g via the two-loop
+// recursion, falling back to steepest descent when the history is empty.
+func (h *history) direction(g []float64) []float64 {
+	q := make([]float64, len(g))
+	copy(q, g)
+
+	n := len(h.s)
+	alpha := make([]float64, n)
+
+	for i := n - 1; i >= 0; i-- {
+		alpha[i] = h.rho[i] * dot(h.s[i], q)
+		axpy(q, -alpha[i], h.y[i])
+	}
+
+	gamma := 1.0
+	if n > 0 {
+		last := n - 1
+		gamma = dot(h.s[last], h.y[last]) / dot(h.y[last], h.y[last])
+	}
+
+	r := make([]float64, len(q))
+	for i := range r {
+		r[i] = gamma * q[i]
+	}
+
+	for i := 0; i < n; i++ {
+		beta := h.rho[i] * dot(h.y[i], r)
+		axpy(r, alpha[i]-beta, h.s[i])
+	}
+
+	// r approximates H*g, the Newton step for minimizing; negate for a descent direction.
+	for i := range r {
+		r[i] = -r[i]
+	}
+
+	return r
+}
+
+// dot returns the dot product of a and b.
+func dot(a, b []float64) float64 {
+	var s float64
+	for i := range a {
+		s += a[i] * b[i]
+	}
+
+	return s
+}
+
+// axpy computes a += alpha*b in place.
+func axpy(a []float64, alpha float64, b []float64) {
+	for i := range a {
+		a[i] += alpha * b[i]
+	}
+}
+
+// evalFunc evaluates the objective and its gradient at a candidate point.
+type evalFunc func(x []float64) (float64, []float64, error)
+
+// lineSearch performs a simple backtracking line search with an Armijo sufficient
+// decrease condition along direction dir starting from x, and returns the chosen step size.
+func lineSearch(x, dir []float64, eval evalFunc) float64 {
+	const (
+		c1       = 1e-4
+		shrink   = 0.5
+		maxTries = 20
+		initStep = 1.0
+	)
+
+	f0, g0, err := eval(x)
+	if err != nil {
+		return 0
+	}
+	slope := dot(g0, dir)
+	if slope >= 0 {
+		// dir isn't a descent direction (e.g. a skipped curvature update); bail out safely.
+		return 0
+	}
+
+	step := initStep
+	for try := 0; try < maxTries; try++ {
+		candidate := make([]float64, len(x))
+		for i := range x {
+			candidate[i] = x[i] + step*dir[i]
+		}
+
+		fval, _, err := eval(candidate)
+		if err == nil && fval <= f0+c1*step*slope {
+			return step
+		}
+
+		step *= shrink
+	}
+
+	return 0
+}
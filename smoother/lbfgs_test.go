@@ -0,0 +1,91 @@
+package smoother
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDotAndAxpy(t *testing.T) {
+	a := []float64{1, 2, 3}
+	b := []float64{4, 5, 6}
+
+	if got, want := dot(a, b), 32.0; got != want {
+		t.Errorf("dot(%v, %v) = %f, want %f", a, b, got, want)
+	}
+
+	axpy(a, 2, b)
+	want := []float64{9, 12, 15}
+	for i := range want {
+		if a[i] != want[i] {
+			t.Errorf("axpy result[%d] = %f, want %f", i, a[i], want[i])
+		}
+	}
+}
+
+func TestHistoryDirectionFallsBackToSteepestDescent(t *testing.T) {
+	h := newHistory(5)
+
+	g := []float64{3, -4}
+	dir := h.direction(g)
+
+	for i := range g {
+		if dir[i] != -g[i] {
+			t.Errorf("direction[%d] = %f, want %f (steepest descent with empty history)", i, dir[i], -g[i])
+		}
+	}
+}
+
+func TestHistorySkipsUpdateWhenCurvatureConditionFails(t *testing.T) {
+	h := newHistory(5)
+
+	x, xNext := []float64{0, 0}, []float64{1, 1}
+	g, gNext := []float64{1, 1}, []float64{-1, -1}
+
+	h.update(xNext, x, gNext, g)
+	if len(h.s) != 0 {
+		t.Errorf("Expected update to be skipped when s.y <= 0, got %d entries", len(h.s))
+	}
+}
+
+// TestLBFGSMinimizesQuadratic runs the two-loop recursion with a backtracking line
+// search on f(x) = 0.5*x^T*A*x for a diagonal A, and checks it converges to the minimizer 0.
+func TestLBFGSMinimizesQuadratic(t *testing.T) {
+	A := []float64{4, 1}
+
+	eval := func(x []float64) (float64, []float64, error) {
+		f := 0.5 * (A[0]*x[0]*x[0] + A[1]*x[1]*x[1])
+		g := []float64{A[0] * x[0], A[1] * x[1]}
+		return f, g, nil
+	}
+
+	x := []float64{5, -3}
+	_, g, err := eval(x)
+	if err != nil {
+		t.Fatalf("eval failed: %v", err)
+	}
+
+	hist := newHistory(5)
+	for it := 0; it < 50; it++ {
+		dir := hist.direction(g)
+		step := lineSearch(x, dir, eval)
+
+		xNext := make([]float64, len(x))
+		for i := range x {
+			xNext[i] = x[i] + step*dir[i]
+		}
+
+		_, gNext, err := eval(xNext)
+		if err != nil {
+			t.Fatalf("eval failed: %v", err)
+		}
+
+		hist.update(xNext, x, gNext, g)
+		x, g = xNext, gNext
+	}
+
+	for i, xi := range x {
+		if math.Abs(xi) > 1e-4 {
+			t.Errorf("x[%d] = %f after optimization, want close to 0", i, xi)
+		}
+	}
+}
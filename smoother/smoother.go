@@ -0,0 +1,288 @@
+// Package smoother computes a maximum-a-posteriori (MAP) estimate of a full state
+// trajectory given a batch of observations, as an alternative to a Kalman smoother's
+// fixed-lag window.
+package smoother
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/LIA-Aerospace/go-estimate/sim"
+)
+
+// Smoother computes the MAP trajectory x_{0:T} for a sequence of observations by
+// minimizing the negative log-posterior with L-BFGS.
+type Smoother struct {
+	model sim.Model
+
+	init *sim.InitCond
+	q    mat.Symmetric
+	r    mat.Symmetric
+
+	qInv  mat.Symmetric
+	rInv  mat.Symmetric
+	p0Inv mat.Symmetric
+
+	// mem is the number of past (s, y, rho) triples kept in the L-BFGS history.
+	mem int
+	// iters bounds the number of L-BFGS iterations run by Smooth.
+	iters int
+}
+
+// New creates a Smoother for model with initial condition init, process noise
+// covariance q and measurement noise covariance r, and returns it. mem is the size
+// of the L-BFGS history and iters bounds the number of optimization steps.
+func New(model sim.Model, init *sim.InitCond, q, r mat.Symmetric, mem, iters int) (*Smoother, error) {
+	if model == nil {
+		return nil, fmt.Errorf("Invalid model")
+	}
+
+	qInv, err := invertSym(q)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid process noise covariance: %v", err)
+	}
+
+	rInv, err := invertSym(r)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid measurement noise covariance: %v", err)
+	}
+
+	p0Inv, err := invertSym(init.Cov())
+	if err != nil {
+		return nil, fmt.Errorf("Invalid initial covariance: %v", err)
+	}
+
+	return &Smoother{
+		model: model,
+		init:  init,
+		q:     q,
+		r:     r,
+		qInv:  qInv,
+		rInv:  rInv,
+		p0Inv: p0Inv,
+		mem:   mem,
+		iters: iters,
+	}, nil
+}
+
+// invertSym returns the inverse of a symmetric matrix via its Cholesky factorization.
+func invertSym(a mat.Symmetric) (mat.Symmetric, error) {
+	var chol mat.Cholesky
+	if ok := chol.Factorize(a); !ok {
+		return nil, fmt.Errorf("Cholesky factorization failed")
+	}
+
+	var inv mat.SymDense
+	if err := chol.InverseTo(&inv); err != nil {
+		return nil, err
+	}
+
+	return &inv, nil
+}
+
+// Smooth returns the MAP trajectory x_{0:T} given observations y_1..y_T, initialized
+// by forward-propagating model from the Smoother's initial condition.
+func (s *Smoother) Smooth(obs []mat.Vector) ([]mat.Vector, error) {
+	n := s.init.State().Len()
+	traj := s.forwardInit(obs, n)
+
+	x := pack(traj)
+	g, err := s.gradient(unpack(x, n), obs)
+	if err != nil {
+		return nil, err
+	}
+
+	hist := newHistory(s.mem)
+
+	for it := 0; it < s.iters; it++ {
+		dir := hist.direction(g)
+
+		step := lineSearch(x, dir, func(candidate []float64) (float64, []float64, error) {
+			traj := unpack(candidate, n)
+			fval, err := s.negLogPosterior(traj, obs)
+			if err != nil {
+				return 0, nil, err
+			}
+
+			grad, err := s.gradient(traj, obs)
+			if err != nil {
+				return 0, nil, err
+			}
+
+			return fval, grad, nil
+		})
+
+		xNext := make([]float64, len(x))
+		for i := range x {
+			xNext[i] = x[i] + step*dir[i]
+		}
+
+		gNext, err := s.gradient(unpack(xNext, n), obs)
+		if err != nil {
+			return nil, err
+		}
+
+		hist.update(xNext, x, gNext, g)
+
+		x, g = xNext, gNext
+	}
+
+	return toVectors(unpack(x, n)), nil
+}
+
+// forwardInit seeds the trajectory by propagating model forward from the initial
+// condition with zero process noise.
+func (s *Smoother) forwardInit(obs []mat.Vector, n int) []*mat.VecDense {
+	traj := make([]*mat.VecDense, len(obs)+1)
+
+	x0 := mat.NewVecDense(n, nil)
+	x0.CloneFromVec(s.init.State())
+	traj[0] = x0
+
+	zero := mat.NewVecDense(n, nil)
+	for k := 1; k <= len(obs); k++ {
+		next, err := s.model.Propagate(traj[k-1], nil, zero)
+		xk := mat.NewVecDense(n, nil)
+		if err == nil {
+			xk.CloneFromVec(next)
+		} else {
+			xk.CloneFromVec(traj[k-1])
+		}
+		traj[k] = xk
+	}
+
+	return traj
+}
+
+// negLogPosterior returns the negative log-posterior of trajectory traj given obs.
+func (s *Smoother) negLogPosterior(traj []*mat.VecDense, obs []mat.Vector) (float64, error) {
+	n := traj[0].Len()
+
+	diff0 := mat.NewVecDense(n, nil)
+	diff0.SubVec(traj[0], s.init.State())
+	j := 0.5 * quadForm(diff0, s.p0Inv)
+
+	for k := 1; k < len(traj); k++ {
+		pred, err := s.model.Propagate(traj[k-1], nil, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		dx := mat.NewVecDense(n, nil)
+		dx.SubVec(traj[k], pred)
+		j += 0.5 * quadForm(dx, s.qInv)
+
+		obsPred, err := s.model.Observe(traj[k], nil, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		dy := mat.NewVecDense(obsPred.Len(), nil)
+		dy.SubVec(obs[k-1], obsPred)
+		j += 0.5 * quadForm(dy, s.rInv)
+	}
+
+	return j, nil
+}
+
+// gradient computes the block-tridiagonal gradient of negLogPosterior with respect
+// to each x_k in traj, using the model's Jacobians at each linearization point, and
+// returns it flattened in the same layout as pack/unpack.
+func (s *Smoother) gradient(traj []*mat.VecDense, obs []mat.Vector) ([]float64, error) {
+	n := traj[0].Len()
+	T := len(traj) - 1
+
+	grads := make([]*mat.VecDense, T+1)
+	for k := range grads {
+		grads[k] = mat.NewVecDense(n, nil)
+	}
+
+	diff0 := mat.NewVecDense(n, nil)
+	diff0.SubVec(traj[0], s.init.State())
+	g0 := mat.NewVecDense(n, nil)
+	g0.MulVec(s.p0Inv, diff0)
+	grads[0].AddVec(grads[0], g0)
+
+	for k := 1; k <= T; k++ {
+		pred, err := s.model.Propagate(traj[k-1], nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		Fk := s.model.StateMatrix()
+
+		dx := mat.NewVecDense(n, nil)
+		dx.SubVec(traj[k], pred)
+
+		qdx := mat.NewVecDense(n, nil)
+		qdx.MulVec(s.qInv, dx)
+
+		// process term contribution to x_k
+		grads[k].AddVec(grads[k], qdx)
+
+		// process term contribution to x_{k-1} via -F_k^T * Q^-1 * dx
+		ftQdx := mat.NewVecDense(n, nil)
+		ftQdx.MulVec(Fk.T(), qdx)
+		grads[k-1].SubVec(grads[k-1], ftQdx)
+
+		obsPred, err := s.model.Observe(traj[k], nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		Hk := s.model.OutputMatrix()
+
+		dy := mat.NewVecDense(obsPred.Len(), nil)
+		dy.SubVec(obs[k-1], obsPred)
+
+		rdy := mat.NewVecDense(obsPred.Len(), nil)
+		rdy.MulVec(s.rInv, dy)
+
+		// measurement term contribution to x_k via -H_k^T * R^-1 * dy
+		htRdy := mat.NewVecDense(n, nil)
+		htRdy.MulVec(Hk.T(), rdy)
+		grads[k].SubVec(grads[k], htRdy)
+	}
+
+	return pack(grads), nil
+}
+
+// quadForm returns v^T * a * v.
+func quadForm(v mat.Vector, a mat.Symmetric) float64 {
+	av := mat.NewVecDense(v.Len(), nil)
+	av.MulVec(a, v)
+
+	return mat.Dot(v, av)
+}
+
+// pack flattens a trajectory into a single parameter vector.
+func pack(traj []*mat.VecDense) []float64 {
+	n := traj[0].Len()
+	flat := make([]float64, len(traj)*n)
+	for k, x := range traj {
+		for i := 0; i < n; i++ {
+			flat[k*n+i] = x.AtVec(i)
+		}
+	}
+
+	return flat
+}
+
+// unpack restores a trajectory of states of dimension n from a flattened parameter vector.
+func unpack(flat []float64, n int) []*mat.VecDense {
+	traj := make([]*mat.VecDense, len(flat)/n)
+	for k := range traj {
+		traj[k] = mat.NewVecDense(n, flat[k*n:(k+1)*n])
+	}
+
+	return traj
+}
+
+// toVectors widens a []*mat.VecDense into a []mat.Vector for use by callers.
+func toVectors(traj []*mat.VecDense) []mat.Vector {
+	out := make([]mat.Vector, len(traj))
+	for i, x := range traj {
+		out[i] = x
+	}
+
+	return out
+}
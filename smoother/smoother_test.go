@@ -0,0 +1,168 @@
+package smoother
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/LIA-Aerospace/go-estimate/sim"
+)
+
+// randomWalkModel builds a trivial 1D random-walk model (x_{k+1} = x_k) observed directly.
+func randomWalkModel(t *testing.T) *sim.BaseModel {
+	t.Helper()
+
+	A := mat.NewDense(1, 1, []float64{1})
+	C := mat.NewDense(1, 1, []float64{1})
+
+	m, err := sim.NewBaseModel(A, nil, C, nil)
+	if err != nil {
+		t.Fatalf("NewBaseModel failed: %v", err)
+	}
+
+	return m
+}
+
+func TestSmootherSmoothReducesNegLogPosterior(t *testing.T) {
+	m := randomWalkModel(t)
+
+	init := sim.NewInitCond(mat.NewVecDense(1, []float64{0}), mat.NewSymDense(1, []float64{1}))
+	q := mat.NewSymDense(1, []float64{0.1})
+	r := mat.NewSymDense(1, []float64{0.1})
+
+	s, err := New(m, init, q, r, 5, 20)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	obs := []mat.Vector{
+		mat.NewVecDense(1, []float64{1}),
+		mat.NewVecDense(1, []float64{1.2}),
+		mat.NewVecDense(1, []float64{0.9}),
+		mat.NewVecDense(1, []float64{1.1}),
+	}
+
+	initTraj := s.forwardInit(obs, 1)
+	jBefore, err := s.negLogPosterior(initTraj, obs)
+	if err != nil {
+		t.Fatalf("negLogPosterior failed: %v", err)
+	}
+
+	traj, err := s.Smooth(obs)
+	if err != nil {
+		t.Fatalf("Smooth failed: %v", err)
+	}
+	if len(traj) != len(obs)+1 {
+		t.Fatalf("Smooth returned %d states, want %d", len(traj), len(obs)+1)
+	}
+
+	afterTraj := make([]*mat.VecDense, len(traj))
+	for i, x := range traj {
+		v := mat.NewVecDense(x.Len(), nil)
+		v.CloneFromVec(x)
+		afterTraj[i] = v
+	}
+	jAfter, err := s.negLogPosterior(afterTraj, obs)
+	if err != nil {
+		t.Fatalf("negLogPosterior failed: %v", err)
+	}
+
+	if jAfter > jBefore {
+		t.Errorf("negLogPosterior after Smooth = %f, want <= %f (forward-init baseline)", jAfter, jBefore)
+	}
+}
+
+// rotationModel builds a 2-state NonlinearModel with a non-identity, non-trivial
+// analytic Jacobian (a small discretized rotation) observing only the first state,
+// so the smoother's block-tridiagonal gradient exercises model.StateMatrix()/
+// OutputMatrix() for an actual (non-constant-identity) Jacobian pair.
+func rotationModel(t *testing.T) *sim.NonlinearModel {
+	t.Helper()
+
+	const w = 0.1
+
+	f := func(x, u mat.Vector) mat.Vector {
+		out := mat.NewVecDense(2, nil)
+		out.SetVec(0, x.AtVec(0)+w*x.AtVec(1))
+		out.SetVec(1, x.AtVec(1)-w*x.AtVec(0))
+		return out
+	}
+	fx := func(x, u mat.Vector) mat.Matrix {
+		return mat.NewDense(2, 2, []float64{1, w, -w, 1})
+	}
+
+	h := func(x, u mat.Vector) mat.Vector {
+		out := mat.NewVecDense(1, nil)
+		out.SetVec(0, x.AtVec(0))
+		return out
+	}
+	hx := func(x, u mat.Vector) mat.Matrix {
+		return mat.NewDense(1, 2, []float64{1, 0})
+	}
+
+	m, err := sim.NewNonlinearModel(2, 1, f, h, fx, nil, hx, nil)
+	if err != nil {
+		t.Fatalf("NewNonlinearModel failed: %v", err)
+	}
+
+	return m
+}
+
+func TestSmootherSmoothWithNonlinearModelReducesNegLogPosterior(t *testing.T) {
+	m := rotationModel(t)
+
+	init := sim.NewInitCond(mat.NewVecDense(2, []float64{1, 0}), mat.NewSymDense(2, []float64{1, 0, 0, 1}))
+	q := mat.NewSymDense(2, []float64{0.05, 0, 0, 0.05})
+	r := mat.NewSymDense(1, []float64{0.1})
+
+	s, err := New(m, init, q, r, 5, 20)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	obs := []mat.Vector{
+		mat.NewVecDense(1, []float64{1.05}),
+		mat.NewVecDense(1, []float64{0.95}),
+		mat.NewVecDense(1, []float64{0.8}),
+		mat.NewVecDense(1, []float64{0.6}),
+	}
+
+	initTraj := s.forwardInit(obs, 2)
+	jBefore, err := s.negLogPosterior(initTraj, obs)
+	if err != nil {
+		t.Fatalf("negLogPosterior failed: %v", err)
+	}
+
+	traj, err := s.Smooth(obs)
+	if err != nil {
+		t.Fatalf("Smooth failed: %v", err)
+	}
+	if len(traj) != len(obs)+1 {
+		t.Fatalf("Smooth returned %d states, want %d", len(traj), len(obs)+1)
+	}
+
+	afterTraj := make([]*mat.VecDense, len(traj))
+	for i, x := range traj {
+		v := mat.NewVecDense(x.Len(), nil)
+		v.CloneFromVec(x)
+		afterTraj[i] = v
+	}
+	jAfter, err := s.negLogPosterior(afterTraj, obs)
+	if err != nil {
+		t.Fatalf("negLogPosterior failed: %v", err)
+	}
+
+	if jAfter > jBefore {
+		t.Errorf("negLogPosterior after Smooth = %f, want <= %f (forward-init baseline)", jAfter, jBefore)
+	}
+}
+
+func TestNewSmootherInvalidModel(t *testing.T) {
+	init := sim.NewInitCond(mat.NewVecDense(1, []float64{0}), mat.NewSymDense(1, []float64{1}))
+	q := mat.NewSymDense(1, []float64{0.1})
+	r := mat.NewSymDense(1, []float64{0.1})
+
+	if _, err := New(nil, init, q, r, 5, 20); err == nil {
+		t.Error("Expected error for nil model")
+	}
+}